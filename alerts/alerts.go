@@ -0,0 +1,84 @@
+// Package alerts defines the pluggable notification sinks used to page
+// or message operators when an ECS task stop looks like an incident.
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// tracer instruments alert delivery: one child span per sink notification.
+var tracer = otel.Tracer("github.com/Debbatisudheer/Ecs-task-checker/alerts")
+
+// AlertEvent carries everything an AlertSink needs to render and send a
+// notification, independent of where the underlying data came from.
+type AlertEvent struct {
+	Severity      string
+	Detector      string
+	Summary       string
+	ServiceName   string
+	TaskArn       string
+	Rule          string
+	Status        string
+	Timestamp     string
+	CustomDetails map[string]interface{}
+	// DedupKey, when set, is passed through to sinks that support
+	// incident merging (e.g. PagerDuty's dedup_key) so repeated events
+	// for the same underlying issue update one incident instead of
+	// opening a new one each time.
+	DedupKey string
+}
+
+// AlertSink delivers an AlertEvent to a downstream notification system
+// such as PagerDuty, Slack, or Opsgenie.
+type AlertSink interface {
+	// Notify sends the event and returns an error if delivery failed.
+	Notify(ctx context.Context, event AlertEvent) error
+	// Name identifies the sink for logging and configuration.
+	Name() string
+}
+
+// Dispatch sends event to every sink concurrently, giving each one up to
+// timeout to complete, and returns a combined error for any sinks that
+// failed or timed out. A nil error means every sink succeeded.
+func Dispatch(ctx context.Context, sinks []AlertSink, event AlertEvent, timeout time.Duration) error {
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(sinks))
+
+	for _, sink := range sinks {
+		wg.Add(1)
+		go func(s AlertSink) {
+			defer wg.Done()
+
+			sinkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			sinkCtx, span := tracer.Start(sinkCtx, "alerts.Notify "+s.Name())
+			defer span.End()
+
+			if err := s.Notify(sinkCtx, event); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				errCh <- fmt.Errorf("%s: %w", s.Name(), err)
+			}
+		}(sink)
+	}
+
+	wg.Wait()
+	close(errCh)
+
+	var failures []string
+	for err := range errCh {
+		failures = append(failures, err.Error())
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("alert dispatch failed for %d sink(s): %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpsgenieSink sends alerts to the Opsgenie Alerts API.
+type OpsgenieSink struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// NewOpsgenieSink builds an OpsgenieSink with a default HTTP client.
+func NewOpsgenieSink(apiKey string) *OpsgenieSink {
+	return &OpsgenieSink{
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *OpsgenieSink) Name() string { return "opsgenie" }
+
+// Notify creates an Opsgenie alert, using the task ARN as the dedup alias
+// so repeated stops on the same task update one alert instead of piling up.
+func (s *OpsgenieSink) Notify(ctx context.Context, event AlertEvent) error {
+	payload := map[string]interface{}{
+		"message":     event.Summary,
+		"alias":       event.TaskArn,
+		"description": fmt.Sprintf("%s: %s", event.Detector, event.Status),
+		"details":     event.CustomDetails,
+		"priority":    opsgeniePriority(event.Severity),
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal opsgenie payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.opsgenie.com/v2/alerts", bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("create opsgenie request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+s.APIKey)
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send opsgenie request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected opsgenie response status: %s, body: %s", resp.Status, string(respBody))
+	}
+	return nil
+}
+
+// opsgeniePriority maps the loosely-typed severity strings coming out of
+// Splunk onto Opsgenie's P1-P5 priority scale.
+func opsgeniePriority(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return "P1"
+	case "major", "high":
+		return "P2"
+	case "minor", "medium":
+		return "P3"
+	case "warning", "low":
+		return "P4"
+	default:
+		return "P3"
+	}
+}
@@ -0,0 +1,137 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Debbatisudheer/Ecs-task-checker/internal/retry"
+)
+
+// PagerDutySink sends alerts to the PagerDuty Events API v2, retrying
+// transient failures with exponential backoff.
+type PagerDutySink struct {
+	RoutingKey  string
+	HTTPClient  *http.Client
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NewPagerDutySink builds a PagerDutySink with a default HTTP client and
+// retry policy (5 attempts, 200ms base delay, 10s max delay).
+func NewPagerDutySink(routingKey string) *PagerDutySink {
+	return &PagerDutySink{
+		RoutingKey:  routingKey,
+		HTTPClient:  &http.Client{Timeout: 5 * time.Second},
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+func (s *PagerDutySink) Name() string { return "pagerduty" }
+
+// httpStatusError models a non-2xx PagerDuty response so isRetryable
+// can tell a transient failure from a terminal one.
+type httpStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected pagerduty response status: %s", e.Status)
+}
+
+// isRetryable reports whether err represents a transient PagerDuty
+// delivery failure: an HTTP 429/5xx response, or anything else (a
+// network-level timeout or connection error, since the request URL is
+// static and can't itself be malformed).
+func isRetryable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// Notify posts the event to PagerDuty's enqueue endpoint, retrying on
+// 429/5xx responses (honoring Retry-After) and network errors.
+func (s *PagerDutySink) Notify(ctx context.Context, event AlertEvent) error {
+	payload := map[string]interface{}{
+		"routing_key":  s.RoutingKey,
+		"event_action": "trigger",
+		"payload": map[string]interface{}{
+			"summary":        event.Summary,
+			"source":         event.TaskArn,
+			"severity":       event.Severity,
+			"component":      "Eventing",
+			"group":          "prod",
+			"class":          "Availability",
+			"custom_details": event.CustomDetails,
+		},
+	}
+	if event.DedupKey != "" {
+		payload["dedup_key"] = event.DedupKey
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal pagerduty event: %w", err)
+	}
+
+	policy := retry.Policy{MaxAttempts: s.MaxAttempts, BaseDelay: s.BaseDelay, MaxDelay: s.MaxDelay}
+
+	return retry.Do(ctx, policy, isRetryable, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", "https://events.pagerduty.com/v2/enqueue", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("create pagerduty request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := s.HTTPClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("send pagerduty request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		respBody, _ := io.ReadAll(resp.Body)
+		slog.InfoContext(ctx, "pagerduty response",
+			"status", resp.Status,
+			"body", string(respBody),
+			"task_arn", event.TaskArn,
+			"service", event.ServiceName,
+			"dedup_key", event.DedupKey,
+		)
+
+		if resp.StatusCode == http.StatusAccepted {
+			return nil
+		}
+
+		statusErr := &httpStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+		if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > 0 {
+			return &retry.RetryAfterError{Err: statusErr, RetryAfter: retryAfter}
+		}
+		return statusErr
+	})
+}
+
+// parseRetryAfter parses an HTTP Retry-After header expressed in
+// seconds; it returns 0 if the header is absent or not a plain integer.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
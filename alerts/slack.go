@@ -0,0 +1,56 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SlackSink posts alerts to a Slack incoming webhook.
+type SlackSink struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewSlackSink builds a SlackSink with a default HTTP client.
+func NewSlackSink(webhookURL string) *SlackSink {
+	return &SlackSink{
+		WebhookURL: webhookURL,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *SlackSink) Name() string { return "slack" }
+
+// Notify posts a simple text message to the configured webhook.
+func (s *SlackSink) Notify(ctx context.Context, event AlertEvent) error {
+	text := fmt.Sprintf("*%s* (%s)\nservice: %s\ntask: %s\nstatus: %s",
+		event.Summary, event.Detector, event.ServiceName, event.TaskArn, event.Status)
+
+	body, err := json.Marshal(map[string]interface{}{"text": text})
+	if err != nil {
+		return fmt.Errorf("marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.WebhookURL, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send slack request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected slack response status: %s, body: %s", resp.Status, string(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Package classify turns the raw stop signals ECS attaches to a task
+// (StoppedReason, StopCode, per-container exit reasons) into a Category
+// and a should-alert decision, via a declarative, operator-tunable rule
+// table instead of hard-coded substring checks.
+package classify
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Category is the classification assigned to a stopped ECS task.
+type Category string
+
+const (
+	CategoryMaintenance            Category = "Maintenance"
+	CategoryScaling                Category = "Scaling"
+	CategoryOOMKilled              Category = "OOMKilled"
+	CategoryEssentialContainerExit Category = "EssentialContainerExit"
+	CategoryHealthCheckFailed      Category = "HealthCheckFailed"
+	CategoryDeploymentReplacement  Category = "DeploymentReplacement"
+	CategorySpotInterruption       Category = "SpotInterruption"
+	CategoryHostTerminated         Category = "HostTerminated"
+	CategoryUnknown                Category = "Unknown"
+)
+
+// ContainerInput is the subset of an ECS task container's stop signal
+// the rule engine inspects.
+type ContainerInput struct {
+	Reason   string
+	ExitCode *int32
+}
+
+// TaskInput is the subset of ECS task state the rule engine inspects.
+type TaskInput struct {
+	StoppedReason string
+	StopCode      string
+	Containers    []ContainerInput
+}
+
+// Rule maps a substring pattern found in one stop signal field onto a
+// Category and whether tasks in that category should alert. Field is
+// one of "stopped_reason", "stop_code", "container_reason", or
+// "container_exit_code".
+type Rule struct {
+	Field       string   `json:"field"`
+	Pattern     string   `json:"pattern"`
+	Category    Category `json:"category"`
+	ShouldAlert bool     `json:"should_alert"`
+}
+
+// DefaultRules is the built-in rule table, evaluated in order, used when
+// no operator override is configured.
+func DefaultRules() []Rule {
+	return []Rule{
+		{Field: "stopped_reason", Pattern: "maintenance", Category: CategoryMaintenance, ShouldAlert: false},
+		{Field: "stopped_reason", Pattern: "scaling", Category: CategoryScaling, ShouldAlert: false},
+		{Field: "stop_code", Pattern: "spotinterruption", Category: CategorySpotInterruption, ShouldAlert: false},
+		{Field: "stopped_reason", Pattern: "spot interruption", Category: CategorySpotInterruption, ShouldAlert: false},
+		{Field: "stopped_reason", Pattern: "host terminated", Category: CategoryHostTerminated, ShouldAlert: false},
+		{Field: "stopped_reason", Pattern: "deployment", Category: CategoryDeploymentReplacement, ShouldAlert: false},
+		{Field: "container_reason", Pattern: "outofmemory", Category: CategoryOOMKilled, ShouldAlert: true},
+		{Field: "container_exit_code", Pattern: "137", Category: CategoryOOMKilled, ShouldAlert: true},
+		{Field: "stopped_reason", Pattern: "health check failed", Category: CategoryHealthCheckFailed, ShouldAlert: true},
+		{Field: "container_reason", Pattern: "essential container", Category: CategoryEssentialContainerExit, ShouldAlert: true},
+	}
+}
+
+// Engine classifies stopped ECS tasks using an ordered rule table.
+type Engine struct {
+	rules       []Rule
+	shouldAlert map[Category]bool
+}
+
+// NewEngine builds a classification engine from an explicit rule table.
+// CategoryUnknown always alerts: it's the fallback for stop signals no
+// rule recognizes, so suppressing it would silently hide new failure
+// modes. This holds regardless of rules: a rule targeting
+// Category: "Unknown" is recorded for Classify's matching but cannot
+// flip its should-alert bit.
+func NewEngine(rules []Rule) *Engine {
+	shouldAlert := map[Category]bool{}
+	for _, rule := range rules {
+		shouldAlert[rule.Category] = rule.ShouldAlert
+	}
+	shouldAlert[CategoryUnknown] = true
+	return &Engine{rules: rules, shouldAlert: shouldAlert}
+}
+
+// Classify walks the rule table in order and returns the first matching
+// category, falling back to CategoryUnknown if nothing matches.
+func (e *Engine) Classify(task TaskInput) Category {
+	reason := strings.ToLower(task.StoppedReason)
+	stopCode := strings.ToLower(task.StopCode)
+
+	for _, rule := range e.rules {
+		pattern := strings.ToLower(rule.Pattern)
+
+		switch rule.Field {
+		case "stopped_reason":
+			if strings.Contains(reason, pattern) {
+				return rule.Category
+			}
+		case "stop_code":
+			if strings.Contains(stopCode, pattern) {
+				return rule.Category
+			}
+		case "container_reason":
+			for _, c := range task.Containers {
+				if strings.Contains(strings.ToLower(c.Reason), pattern) {
+					return rule.Category
+				}
+			}
+		case "container_exit_code":
+			for _, c := range task.Containers {
+				if c.ExitCode != nil && strconv.Itoa(int(*c.ExitCode)) == pattern {
+					return rule.Category
+				}
+			}
+		}
+	}
+
+	return CategoryUnknown
+}
+
+// ShouldAlert reports whether tasks classified into category should
+// trigger an alert.
+func (e *Engine) ShouldAlert(category Category) bool {
+	return e.shouldAlert[category]
+}
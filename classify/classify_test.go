@@ -0,0 +1,57 @@
+package classify
+
+import "testing"
+
+func TestClassifyMatchesFirstRuleInOrder(t *testing.T) {
+	rules := []Rule{
+		{Field: "stopped_reason", Pattern: "maintenance", Category: CategoryMaintenance, ShouldAlert: false},
+		{Field: "stopped_reason", Pattern: "maintenance window", Category: CategoryScaling, ShouldAlert: true},
+	}
+	engine := NewEngine(rules)
+
+	category := engine.Classify(TaskInput{StoppedReason: "host in maintenance window"})
+	if category != CategoryMaintenance {
+		t.Fatalf("expected first matching rule to win, got %s", category)
+	}
+}
+
+func TestClassifyFallsBackToUnknown(t *testing.T) {
+	engine := NewEngine(DefaultRules())
+
+	category := engine.Classify(TaskInput{StoppedReason: "something nobody has a rule for"})
+	if category != CategoryUnknown {
+		t.Fatalf("expected CategoryUnknown, got %s", category)
+	}
+}
+
+func TestClassifyMatchesContainerExitCode(t *testing.T) {
+	engine := NewEngine(DefaultRules())
+	exitCode := int32(137)
+
+	category := engine.Classify(TaskInput{Containers: []ContainerInput{{ExitCode: &exitCode}}})
+	if category != CategoryOOMKilled {
+		t.Fatalf("expected CategoryOOMKilled, got %s", category)
+	}
+}
+
+func TestShouldAlertReflectsRuleTable(t *testing.T) {
+	engine := NewEngine(DefaultRules())
+
+	if engine.ShouldAlert(CategoryMaintenance) {
+		t.Fatal("expected CategoryMaintenance not to alert")
+	}
+	if !engine.ShouldAlert(CategoryOOMKilled) {
+		t.Fatal("expected CategoryOOMKilled to alert")
+	}
+}
+
+func TestShouldAlertUnknownIgnoresRuleOverride(t *testing.T) {
+	rules := []Rule{
+		{Field: "stopped_reason", Pattern: "maintenance", Category: CategoryUnknown, ShouldAlert: false},
+	}
+	engine := NewEngine(rules)
+
+	if !engine.ShouldAlert(CategoryUnknown) {
+		t.Fatal("expected CategoryUnknown to always alert, even when a rule targets it with should_alert: false")
+	}
+}
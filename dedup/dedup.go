@@ -0,0 +1,101 @@
+// Package dedup suppresses repeat alerts for the same stop event within
+// a configurable window, backed by a DynamoDB table with a TTL
+// attribute.
+package dedup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	ddbTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// Key computes a stable dedup identifier for a stop event. The same key
+// is used as the DynamoDB partition key and as PagerDuty's dedup_key so
+// repeated triggers merge into one incident.
+func Key(serviceName, taskArn, stoppedReason string) string {
+	normalized := strings.ToLower(strings.TrimSpace(stoppedReason))
+	sum := sha256.Sum256([]byte(serviceName + "|" + taskArn + "|" + normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// ClientAPI is the subset of the DynamoDB client the Store needs.
+type ClientAPI interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+}
+
+// Store checks and records recently-alerted dedup keys in a DynamoDB
+// table, so a flapping service doesn't re-page on every stopped task
+// within the suppression window.
+type Store struct {
+	Client    ClientAPI
+	TableName string
+	Window    time.Duration
+}
+
+// NewStore builds a Store for the given table, suppressing repeats
+// within window.
+func NewStore(client ClientAPI, tableName string, window time.Duration) *Store {
+	return &Store{Client: client, TableName: tableName, Window: window}
+}
+
+// Seen reports whether dedupKey was already alerted within the
+// suppression window. If not, it records dedupKey with a TTL so the
+// next check within the window finds it.
+//
+// DynamoDB's TTL sweep is best-effort and can leave expired items in
+// the table (and visible to GetItem) for a significant time after
+// expires_at passes, so Seen can't trust "item exists" alone: it
+// compares expires_at against now and treats an expired-but-undeleted
+// item as not seen.
+func (s *Store) Seen(ctx context.Context, dedupKey string) (bool, error) {
+	out, err := s.Client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.TableName),
+		Key: map[string]ddbTypes.AttributeValue{
+			"dedup_key": &ddbTypes.AttributeValueMemberS{Value: dedupKey},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("get dedup item: %w", err)
+	}
+	if out.Item != nil && !itemExpired(out.Item) {
+		return true, nil
+	}
+
+	expiresAt := time.Now().Add(s.Window).Unix()
+	_, err = s.Client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.TableName),
+		Item: map[string]ddbTypes.AttributeValue{
+			"dedup_key":  &ddbTypes.AttributeValueMemberS{Value: dedupKey},
+			"expires_at": &ddbTypes.AttributeValueMemberN{Value: strconv.FormatInt(expiresAt, 10)},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("put dedup item: %w", err)
+	}
+
+	return false, nil
+}
+
+// itemExpired reports whether item's expires_at attribute is in the
+// past. A missing or malformed expires_at is treated as expired so a
+// corrupt record never wedges a key into permanent suppression.
+func itemExpired(item map[string]ddbTypes.AttributeValue) bool {
+	attr, ok := item["expires_at"].(*ddbTypes.AttributeValueMemberN)
+	if !ok {
+		return true
+	}
+	expiresAt, err := strconv.ParseInt(attr.Value, 10, 64)
+	if err != nil {
+		return true
+	}
+	return time.Now().Unix() >= expiresAt
+}
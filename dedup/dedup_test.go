@@ -0,0 +1,77 @@
+package dedup
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	ddbTypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// fakeClient is an in-memory stand-in for the DynamoDB client, keyed by
+// dedup_key, so Seen can be tested without a real table.
+type fakeClient struct {
+	items map[string]map[string]ddbTypes.AttributeValue
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{items: map[string]map[string]ddbTypes.AttributeValue{}}
+}
+
+func (f *fakeClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	key := params.Key["dedup_key"].(*ddbTypes.AttributeValueMemberS).Value
+	return &dynamodb.GetItemOutput{Item: f.items[key]}, nil
+}
+
+func (f *fakeClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	key := params.Item["dedup_key"].(*ddbTypes.AttributeValueMemberS).Value
+	f.items[key] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeClient) putExpiresAt(key string, expiresAt time.Time) {
+	f.items[key] = map[string]ddbTypes.AttributeValue{
+		"dedup_key":  &ddbTypes.AttributeValueMemberS{Value: key},
+		"expires_at": &ddbTypes.AttributeValueMemberN{Value: strconv.FormatInt(expiresAt.Unix(), 10)},
+	}
+}
+
+func TestSeenFalseThenTrueWithinWindow(t *testing.T) {
+	client := newFakeClient()
+	store := NewStore(client, "table", 30*time.Minute)
+
+	seen, err := store.Seen(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen {
+		t.Fatal("expected first check to report not seen")
+	}
+
+	seen, err = store.Seen(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !seen {
+		t.Fatal("expected second check within the window to report seen")
+	}
+}
+
+func TestSeenFalseWhenItemExpiredButUndeleted(t *testing.T) {
+	client := newFakeClient()
+	store := NewStore(client, "table", 30*time.Minute)
+
+	// Simulate DynamoDB's best-effort TTL sweep: the item's expires_at
+	// is in the past, but it's still physically present in the table.
+	client.putExpiresAt("key-1", time.Now().Add(-time.Hour))
+
+	seen, err := store.Seen(context.Background(), "key-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen {
+		t.Fatal("expected an expired-but-undeleted item to report not seen")
+	}
+}
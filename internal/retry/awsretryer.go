@@ -0,0 +1,35 @@
+package retry
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsretry "github.com/aws/aws-sdk-go-v2/aws/retry"
+)
+
+// throttlingErrorCodes are the smithy error codes that indicate an AWS
+// API call was throttled and should be retried. ThrottlingException and
+// RequestLimitExceeded are already in the standard retryer's default
+// list; they're named here for clarity and to survive upstream default
+// changes.
+var throttlingErrorCodes = []string{
+	"ThrottlingException",
+	"RequestLimitExceeded",
+	"ServiceUnavailable",
+}
+
+// NewAWSRetryer builds an aws.Retryer for ECS/Secrets Manager clients
+// that retries throttling errors using the same full-jitter backoff as
+// Do, capped at maxAttempts total attempts.
+func NewAWSRetryer(maxAttempts int, base, max time.Duration) aws.Retryer {
+	standard := awsretry.NewStandard(func(o *awsretry.StandardOptions) {
+		o.MaxAttempts = maxAttempts
+		o.MaxBackoff = max
+		o.Backoff = awsretry.BackoffDelayerFunc(func(attempt int, _ error) (time.Duration, error) {
+			return Backoff(base, max, attempt, rand.Int63n), nil
+		})
+	})
+
+	return awsretry.AddWithErrorCodes(standard, throttlingErrorCodes...)
+}
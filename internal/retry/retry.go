@@ -0,0 +1,113 @@
+// Package retry provides a small exponential-backoff-with-full-jitter
+// helper for operations that can fail transiently (PagerDuty delivery,
+// AWS API throttling), plus an adapter so the same backoff math drives
+// the AWS SDK's own retryer.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// Policy configures how Do retries an operation.
+type Policy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	// (a value of 1 means no retries).
+	MaxAttempts int
+	// BaseDelay is the upper bound of the jittered delay before the first
+	// retry; the bound doubles with each later retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay regardless of attempt count.
+	MaxDelay time.Duration
+
+	// clock, if set, is used instead of time.After to wait between
+	// attempts. Tests set this to make retry timing deterministic.
+	clock func(d time.Duration) <-chan time.Time
+	// randInt63n, if set, replaces rand.Int63n so jitter is
+	// deterministic in tests.
+	randInt63n func(n int64) int64
+}
+
+// Retryable reports whether err represents a transient condition worth
+// retrying.
+type Retryable func(err error) bool
+
+// RetryAfterError wraps an error with a server-specified minimum delay
+// (e.g. an HTTP Retry-After header) that the next attempt must wait at
+// least as long as.
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// Do runs op up to policy.MaxAttempts times in total, using full-jitter
+// exponential backoff between attempts. It honors ctx cancellation
+// while waiting, and only retries errors for which retryable(err)
+// returns true; any other error is returned immediately.
+func Do(ctx context.Context, policy Policy, retryable Retryable, op func(ctx context.Context) error) error {
+	var err error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		err = op(ctx)
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) {
+			return err
+		}
+		if attempt == policy.MaxAttempts-1 {
+			break
+		}
+
+		delay := Backoff(policy.BaseDelay, policy.MaxDelay, attempt, policy.randFn())
+
+		var retryAfter *RetryAfterError
+		if errors.As(err, &retryAfter) && retryAfter.RetryAfter > delay {
+			delay = retryAfter.RetryAfter
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-policy.after(delay):
+		}
+	}
+	return err
+}
+
+func (p Policy) randFn() func(int64) int64 {
+	if p.randInt63n != nil {
+		return p.randInt63n
+	}
+	return rand.Int63n
+}
+
+func (p Policy) after(d time.Duration) <-chan time.Time {
+	if p.clock != nil {
+		return p.clock(d)
+	}
+	return time.After(d)
+}
+
+// Backoff computes a full-jitter exponential backoff delay for the
+// given 0-indexed attempt: a random duration in
+// [0, min(maxDelay, baseDelay<<attempt)).
+func Backoff(base, max time.Duration, attempt int, randInt63n func(int64) int64) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+
+	ceiling := base << attempt
+	if ceiling <= 0 || ceiling > max { // overflowed or exceeds the cap
+		ceiling = max
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(randInt63n(int64(ceiling)))
+}
@@ -0,0 +1,164 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock lets tests drive Do's backoff waits without real sleeping:
+// After returns a channel that already holds a value and records the
+// requested delay.
+type fakeClock struct {
+	delays []time.Duration
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.delays = append(f.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func constantRand(v int64) func(int64) int64 {
+	return func(n int64) int64 {
+		if v >= n {
+			return n - 1
+		}
+		return v
+	}
+}
+
+var errTransient = errors.New("transient")
+var errPermanent = errors.New("permanent")
+
+func alwaysRetryable(err error) bool { return errors.Is(err, errTransient) }
+
+func TestDoSucceedsWithoutRetry(t *testing.T) {
+	clock := &fakeClock{}
+	policy := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second, clock: clock.After, randInt63n: constantRand(0)}
+
+	calls := 0
+	err := Do(context.Background(), policy, alwaysRetryable, func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+	if len(clock.delays) != 0 {
+		t.Fatalf("expected no waits, got %v", clock.delays)
+	}
+}
+
+func TestDoRetriesTransientErrorsUntilSuccess(t *testing.T) {
+	clock := &fakeClock{}
+	policy := Policy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second, clock: clock.After, randInt63n: constantRand(0)}
+
+	calls := 0
+	err := Do(context.Background(), policy, alwaysRetryable, func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+	if len(clock.delays) != 2 {
+		t.Fatalf("expected 2 waits between 3 attempts, got %v", clock.delays)
+	}
+}
+
+func TestDoStopsAfterMaxAttempts(t *testing.T) {
+	clock := &fakeClock{}
+	policy := Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second, clock: clock.After, randInt63n: constantRand(0)}
+
+	calls := 0
+	err := Do(context.Background(), policy, alwaysRetryable, func(ctx context.Context) error {
+		calls++
+		return errTransient
+	})
+
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("expected final error to be errTransient, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoDoesNotRetryNonRetryableErrors(t *testing.T) {
+	clock := &fakeClock{}
+	policy := Policy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Second, clock: clock.After, randInt63n: constantRand(0)}
+
+	calls := 0
+	err := Do(context.Background(), policy, alwaysRetryable, func(ctx context.Context) error {
+		calls++
+		return errPermanent
+	})
+
+	if !errors.Is(err, errPermanent) {
+		t.Fatalf("expected errPermanent, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no retries, got %d calls", calls)
+	}
+}
+
+func TestDoHonorsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// clock is nil here since the ctx.Done() case should win the select
+	// immediately; if Do ever called time.After with a real delay this
+	// test would hang instead of failing fast.
+	policy := Policy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour, randInt63n: constantRand(int64(time.Hour))}
+
+	err := Do(ctx, policy, alwaysRetryable, func(ctx context.Context) error {
+		return errTransient
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDoRespectsRetryAfter(t *testing.T) {
+	clock := &fakeClock{}
+	policy := Policy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second, clock: clock.After, randInt63n: constantRand(0)}
+
+	retryAfter := 30 * time.Second
+	calls := 0
+	err := Do(context.Background(), policy, alwaysRetryable, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return &RetryAfterError{Err: errTransient, RetryAfter: retryAfter}
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if len(clock.delays) != 1 || clock.delays[0] != retryAfter {
+		t.Fatalf("expected wait to be clamped to Retry-After (%v), got %v", retryAfter, clock.delays)
+	}
+}
+
+func TestBackoffIsBoundedByMaxDelay(t *testing.T) {
+	d := Backoff(time.Second, 5*time.Second, 10, constantRand(0))
+	if d < 0 || d >= 5*time.Second {
+		t.Fatalf("expected backoff to be clamped to [0, maxDelay), got %v", d)
+	}
+}
@@ -0,0 +1,85 @@
+// Package telemetry configures the lambda's structured logging and
+// distributed tracing: a JSON slog handler for CloudWatch Logs Insights,
+// and an OpenTelemetry tracer that exports via OTLP when the Lambda OTel
+// extension is present, falling back to a no-op tracer otherwise.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewLogger builds the process-wide structured logger: JSON output on
+// stdout so CloudWatch Logs Insights can query individual fields, with
+// request_id and trace_id attached automatically from ctx so every log
+// line from an invocation can be correlated without threading them
+// through every call site by hand.
+func NewLogger() *slog.Logger {
+	return slog.New(&contextHandler{Handler: slog.NewJSONHandler(os.Stdout, nil)})
+}
+
+// contextHandler enriches every record with the lambda request ID and
+// active trace ID pulled off ctx, if present.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		record.AddAttrs(slog.String("request_id", lc.AwsRequestID))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		record.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// StartTracing registers a global TracerProvider that exports spans over
+// OTLP/HTTP to the endpoint named by OTEL_EXPORTER_OTLP_ENDPOINT (as set
+// up by the AWS Lambda OTel extension). If the variable is unset, it
+// leaves OpenTelemetry's default no-op TracerProvider in place and
+// returns a no-op shutdown func.
+func StartTracing(ctx context.Context, serviceName string) (shutdown func(context.Context) error, err error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build otel resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
@@ -1,33 +1,37 @@
 package main
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Debbatisudheer/Ecs-task-checker/alerts"
+	"github.com/Debbatisudheer/Ecs-task-checker/classify"
+	"github.com/Debbatisudheer/Ecs-task-checker/dedup"
+	"github.com/Debbatisudheer/Ecs-task-checker/internal/retry"
+	"github.com/Debbatisudheer/Ecs-task-checker/internal/telemetry"
+	"github.com/Debbatisudheer/Ecs-task-checker/processor"
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
 	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 )
 
 var Environment string
 
-// Output result struct
-type TaskResult struct {
-	TaskArn       string `json:"task_arn"`
-	StoppedReason string `json:"stopped_reason"`
-	IsMaintenance bool   `json:"is_maintenance"`
-	IsScaling     bool   `json:"is_scaling"`
-}
+// tracer instruments the lambda's hot path: one root span per
+// invocation, child spans around the ECS and Secrets Manager calls.
+var tracer = otel.Tracer("github.com/Debbatisudheer/Ecs-task-checker")
 
 // Splunk payload struct
 type SplunkBody struct {
@@ -50,134 +54,180 @@ type SplunkBody struct {
 	} `json:"dimensions"`
 }
 
-// Helper function to check if stop reason is due to maintenance
-func isMaintenance(reason string) bool {
-	reason = strings.ToLower(reason)
-	return strings.Contains(reason, reasonMaintenance)
+type SecretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
 }
 
-// Helper function to check if stop reason is due to scaling
-func isScaling(reason string) bool {
-	reason = strings.ToLower(reason)
-	return strings.Contains(reason, reasonScaling)
-}
+// getSecret fetches the named secret from Secrets Manager and extracts
+// the given key from its JSON body.
+func getSecret(ctx context.Context, client SecretsManagerAPI, secretName, key string) (string, error) {
+	ctx, span := tracer.Start(ctx, "secretsmanager.GetSecretValue")
+	defer span.End()
 
-// sendPagerDutyAlert sends an alert to PagerDuty
-func sendPagerDutyAlert(ctx context.Context, Severity, Detector string, splunk SplunkBody, taskArn string, secretValue string) error {
-	// custom details map with information extracted from the Splunk event
-	customDetails := map[string]interface{}{
-		"ServiceName": splunk.Dimensions.ServiceName,
-		"detector":    splunk.Detector,
-		"inputs": map[string]interface{}{
-			"signal": map[string]interface{}{
-				"fragment": splunk.Inputs.Signal.Fragment,
-				"key":      splunk.Inputs.Signal.Key,
-				"value":    splunk.Inputs.Signal.Value,
-			},
-		},
-		"rule":      splunk.Rule,
-		"severity":  splunk.Severity,
-		"status":    splunk.Status,
-		"timestamp": splunk.Timestamp,
+	// Call GetSecretValue API
+	input := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
 	}
 
-	// Marshal to JSON for logging
-	customDetailsJSON, err := json.MarshalIndent(customDetails, "", "  ")
+	result, err := client.GetSecretValue(ctx, input)
 	if err != nil {
-		fmt.Printf("Failed to marshal customDetails: %v\n", err)
-	} else {
-		fmt.Printf("Custom Details:\n%s\n", string(customDetailsJSON))
-	}
-
-	// Construct the event payload
-	event := map[string]interface{}{
-		"routing_key":  secretValue,
-		"event_action": "trigger",
-		"payload": map[string]interface{}{
-			"summary":        fmt.Sprintf("Critical Alert: %s (%s)", Severity, Detector),
-			"source":         taskArn,
-			"severity":       Severity,
-			"detector":       Detector,
-			"component":      "Eventing",
-			"group":          "prod",
-			"class":          "Availability",
-			"custom_details": customDetails,
-		},
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to retrieve secret: %w", err)
 	}
 
-	// Marshal the payload into JSON
-	payload, err := json.Marshal(event)
-	if err != nil {
-		return fmt.Errorf("failed to marshal PagerDuty event: %w", err)
+	// Ensure SecretString is not nil
+	if result.SecretString == nil {
+		return "", fmt.Errorf("secret value is empty or binary")
 	}
 
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", "https://events.pagerduty.com/v2/enqueue", bytes.NewBuffer(payload))
+	// Parse the secret string (assumes JSON format)
+	var secretMap map[string]string
+	err = json.Unmarshal([]byte(*result.SecretString), &secretMap)
 	if err != nil {
-		return fmt.Errorf("failed to create PagerDuty request: %w", err)
+		return "", fmt.Errorf("failed to parse secret string: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
-	// Send the request using an HTTP client
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send PagerDuty request: %w", err)
+	value, exists := secretMap[key]
+	if !exists {
+		return "", fmt.Errorf("key %q not found in secret", key)
+	}
+
+	return value, nil
+}
+
+// defaultSinkSecretKeys maps an alert sink name to the field it expects
+// to find inside the shared secrets JSON blob. Override per-sink via
+// ALERT_SINK_<NAME>_KEY.
+var defaultSinkSecretKeys = map[string]string{
+	"pagerduty": "pagerduty_key",
+	"slack":     "slack_webhook_url",
+	"opsgenie":  "opsgenie_api_key",
+}
+
+// loadAlertSinks builds the set of alert sinks enabled via ALERT_SINKS
+// (comma-separated, defaults to "pagerduty"), fetching each sink's
+// credential from the shared secret.
+func loadAlertSinks(ctx context.Context, client SecretsManagerAPI, environment string) ([]alerts.AlertSink, error) {
+	raw := os.Getenv("ALERT_SINKS")
+	if raw == "" {
+		raw = "pagerduty"
 	}
-	defer resp.Body.Close()
 
-	// Read and print the response body
-	body, _ := io.ReadAll(resp.Body)
-	fmt.Printf("PagerDuty response status: %s, body: %s\n", resp.Status, string(body))
+	secretName := "visibility-eventing@" + environment + "_secrets"
+
+	var sinks []alerts.AlertSink
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+
+		secretKey, known := defaultSinkSecretKeys[name]
+		if override := os.Getenv("ALERT_SINK_" + strings.ToUpper(name) + "_KEY"); override != "" {
+			secretKey, known = override, true
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown alert sink %q", name)
+		}
+
+		credential, err := getSecret(ctx, client, secretName, secretKey)
+		if err != nil {
+			return nil, fmt.Errorf("load credential for sink %q: %w", name, err)
+		}
 
-	// Check if the response status code is not 202 (Accepted)
-	if resp.StatusCode != 202 {
-		return fmt.Errorf("unexpected PagerDuty response status: %s", resp.Status)
+		switch name {
+		case "pagerduty":
+			sinks = append(sinks, alerts.NewPagerDutySink(credential))
+		case "slack":
+			sinks = append(sinks, alerts.NewSlackSink(credential))
+		case "opsgenie":
+			sinks = append(sinks, alerts.NewOpsgenieSink(credential))
+		default:
+			return nil, fmt.Errorf("unsupported alert sink %q", name)
+		}
 	}
 
-	return nil
+	return sinks, nil
 }
 
-type SecretsManagerAPI interface {
-	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+// alertSinkTimeout returns the per-sink dispatch timeout, configurable via
+// ALERT_SINK_TIMEOUT_SECONDS (defaults to 5 seconds).
+func alertSinkTimeout() time.Duration {
+	seconds := 5
+	if v := os.Getenv("ALERT_SINK_TIMEOUT_SECONDS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			seconds = n
+		}
+	}
+	return time.Duration(seconds) * time.Second
 }
 
-// Fetch a secret from AWS Secrets Manager
-func getSecret(ctx context.Context, client SecretsManagerAPI, secretName string) (string, error) {
-	// Call GetSecretValue API
-	input := &secretsmanager.GetSecretValueInput{
-		SecretId: aws.String(secretName),
+// loadClassifyRules returns the rule table used to categorize ECS stop
+// reasons, preferring an operator override over the built-in defaults.
+// The override can come from the env var CLASSIFY_RULES_JSON (inline
+// JSON) or, if CLASSIFY_RULES_SECRET_KEY is set, a key in the same
+// shared secret used for alert sink credentials.
+func loadClassifyRules(ctx context.Context, client SecretsManagerAPI, environment string) ([]classify.Rule, error) {
+	if raw := os.Getenv("CLASSIFY_RULES_JSON"); raw != "" {
+		var rules []classify.Rule
+		if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+			return nil, fmt.Errorf("parse CLASSIFY_RULES_JSON: %w", err)
+		}
+		return rules, nil
 	}
 
-	result, err := client.GetSecretValue(ctx, input)
+	secretKey := os.Getenv("CLASSIFY_RULES_SECRET_KEY")
+	if secretKey == "" {
+		return classify.DefaultRules(), nil
+	}
+
+	secretName := "visibility-eventing@" + environment + "_secrets"
+	raw, err := getSecret(ctx, client, secretName, secretKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve secret: %w", err)
+		return nil, fmt.Errorf("load classify rules: %w", err)
 	}
 
-	// Ensure SecretString is not nil
-	if result.SecretString == nil {
-		return "", fmt.Errorf("secret value is empty or binary")
+	var rules []classify.Rule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil, fmt.Errorf("parse classify rules secret: %w", err)
 	}
+	return rules, nil
+}
 
-	// Parse the secret string (assumes JSON format)
-	var secretMap map[string]string
-	err = json.Unmarshal([]byte(*result.SecretString), &secretMap)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse secret string: %w", err)
+// loadProcessorOptions builds the dedup/grouping options used by
+// Process. Deduplication is enabled only when DEDUP_TABLE_NAME is set;
+// its suppression window defaults to 30 minutes, overridable via
+// DEDUP_TTL_MINUTES. Grouping is enabled via ALERT_MODE=grouped
+// (default "individual").
+func loadProcessorOptions(dynamoClient dedup.ClientAPI) processor.Options {
+	opts := processor.Options{
+		Group: strings.EqualFold(os.Getenv("ALERT_MODE"), "grouped"),
 	}
 
-	// Extract the pagerduty_key value
-	key := "pagerduty_key"
-	value, exists := secretMap[key]
-	if !exists {
-		return "", fmt.Errorf("key %q not found in secret", key)
+	tableName := os.Getenv("DEDUP_TABLE_NAME")
+	if tableName == "" {
+		return opts
 	}
 
-	return value, nil
+	window := 30 * time.Minute
+	if v := os.Getenv("DEDUP_TTL_MINUTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			window = time.Duration(n) * time.Minute
+		}
+	}
+
+	opts.Deduper = dedup.NewStore(dynamoClient, tableName, window)
+	return opts
 }
 
-// Lambda handler
-func handler(ctx context.Context, rawEvent json.RawMessage, client *ecs.Client, secretValue string) ([]TaskResult, error) {
+// handler is the Splunk-polling entry point: it looks up the service's
+// recently stopped tasks in ECS, then hands them to the shared
+// processor pipeline.
+func handler(ctx context.Context, rawEvent json.RawMessage, client *ecs.Client, sinks []alerts.AlertSink, engine *classify.Engine, opts processor.Options) ([]processor.TaskResult, error) {
+	ctx, span := tracer.Start(ctx, "handler")
+	defer span.End()
+
 	// Extract the body from the outer Lambda event
 	var outerEvent struct {
 		Body string `json:"body"`
@@ -193,14 +243,15 @@ func handler(ctx context.Context, rawEvent json.RawMessage, client *ecs.Client,
 		return nil, fmt.Errorf("failed to parse inner body: %w", err)
 	}
 
-	fmt.Printf("----- SplunkBody Details -----\n")
-	fmt.Printf("Severity : %s\n", splunk.Severity)
-	fmt.Printf("OriginatingMetric : %s\n", splunk.OriginatingMetric)
-	fmt.Printf("Description : %s\n", splunk.Description)
-	fmt.Printf("Status : %s\n", splunk.Status)
-	fmt.Printf("Timestamp : %s\n", splunk.Timestamp)
-	fmt.Printf("Detector : %s\n", splunk.Detector)
-	fmt.Printf("ServiceName : %s\n", splunk.Dimensions.ServiceName)
+	slog.InfoContext(ctx, "received splunk event",
+		"service", splunk.Dimensions.ServiceName,
+		"severity", splunk.Severity,
+		"originating_metric", splunk.OriginatingMetric,
+		"description", splunk.Description,
+		"status", splunk.Status,
+		"timestamp", splunk.Timestamp,
+		"detector", splunk.Detector,
+	)
 
 	// Validate service name
 	if splunk.Dimensions.ServiceName == "" {
@@ -238,26 +289,31 @@ func handler(ctx context.Context, rawEvent json.RawMessage, client *ecs.Client,
 	// Log custom details to CloudWatch
 	customDetailsJSON, err := json.MarshalIndent(customDetails, "", "  ")
 	if err != nil {
-		fmt.Printf("Failed to marshal customDetails: %v\n", err)
+		slog.WarnContext(ctx, "failed to marshal custom details", "error", err)
 	} else {
-		fmt.Printf("Custom Details:\n%s\n", string(customDetailsJSON))
+		slog.InfoContext(ctx, "custom details", "custom_details", string(customDetailsJSON))
 	}
 
 	// Step 3: List recently stopped tasks for the input service in the specified ECS cluster
-	listOut, err := client.ListTasks(ctx, &ecs.ListTasksInput{
-		Cluster:      aws.String(Environment),
-		ServiceName:  aws.String(splunk.Dimensions.ServiceName),
+	listCtx, listSpan := tracer.Start(ctx, "ecs.ListTasks")
+	listOut, err := client.ListTasks(listCtx, &ecs.ListTasksInput{
+		Cluster:       aws.String(Environment),
+		ServiceName:   aws.String(splunk.Dimensions.ServiceName),
 		DesiredStatus: ecsTypes.DesiredStatusStopped,
-		MaxResults:   aws.Int32(10),
+		MaxResults:    aws.Int32(10),
 	})
 	if err != nil {
+		listSpan.RecordError(err)
+		listSpan.SetStatus(codes.Error, err.Error())
+		listSpan.End()
 		return nil, fmt.Errorf("list tasks: %w", err)
 	}
+	listSpan.End()
 
 	// Step 4: Return error if no stopped tasks found
 	if len(listOut.TaskArns) == 0 {
-		fmt.Printf("No stopped tasks found for service %s in cluster %s\n", splunk.Dimensions.ServiceName, Environment)
-		return []TaskResult{}, nil
+		slog.InfoContext(ctx, "no stopped tasks found", "service", splunk.Dimensions.ServiceName, "cluster", Environment)
+		return []processor.TaskResult{}, nil
 	}
 
 	// Check for cancellation before DescribeTasks API call
@@ -268,78 +324,148 @@ func handler(ctx context.Context, rawEvent json.RawMessage, client *ecs.Client,
 	}
 
 	// Step 5: Describe the stopped tasks to get detailed information
-	descOut, err := client.DescribeTasks(ctx, &ecs.DescribeTasksInput{
+	descCtx, descSpan := tracer.Start(ctx, "ecs.DescribeTasks")
+	descOut, err := client.DescribeTasks(descCtx, &ecs.DescribeTasksInput{
 		Cluster: aws.String(Environment),
 		Tasks:   listOut.TaskArns,
 	})
 	if err != nil {
+		descSpan.RecordError(err)
+		descSpan.SetStatus(codes.Error, err.Error())
+		descSpan.End()
 		return nil, fmt.Errorf("describe tasks: %w", err)
 	}
+	descSpan.End()
+
+	meta := processor.AlertMeta{
+		Severity:      splunk.Severity,
+		Detector:      splunk.Detector,
+		Rule:          splunk.Rule,
+		Status:        splunk.Status,
+		Timestamp:     splunk.Timestamp,
+		ServiceName:   splunk.Dimensions.ServiceName,
+		CustomDetails: customDetails,
+	}
 
-	var results []TaskResult
-	for _, t := range descOut.Tasks {
-		// Check for context cancelation inside the loop
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("operation canceled during loop: %w", ctx.Err())
-		default:
-		}
+	return processor.Process(ctx, descOut.Tasks, meta, engine, sinks, alertSinkTimeout(), opts)
+}
+
+// ecsTaskStateChangeEvent is the EventBridge "ECS Task State Change"
+// event shape, trimmed to the fields the classification pipeline needs.
+type ecsTaskStateChangeEvent struct {
+	Detail struct {
+		TaskArn       string `json:"taskArn"`
+		StoppedReason string `json:"stoppedReason"`
+		StopCode      string `json:"stopCode"`
+		Containers    []struct {
+			Reason   string `json:"reason"`
+			ExitCode *int32 `json:"exitCode"`
+		} `json:"containers"`
+	} `json:"detail"`
+}
 
-		taskArn := aws.ToString(t.TaskArn)
-		reason := strings.ToLower(aws.ToString(t.StoppedReason))
-		isMaint := isMaintenance(reason)
-		isScale := isScaling(reason)
+// eventBridgeHandler is the EventBridge-driven entry point: it takes the
+// task state straight off the event instead of polling ECS, builds the
+// equivalent ecsTypes.Task, and hands it to the shared processor
+// pipeline.
+func eventBridgeHandler(ctx context.Context, rawEvent json.RawMessage, sinks []alerts.AlertSink, engine *classify.Engine, opts processor.Options) ([]processor.TaskResult, error) {
+	ctx, span := tracer.Start(ctx, "eventBridgeHandler")
+	defer span.End()
+
+	var evt ecsTaskStateChangeEvent
+	if err := json.Unmarshal(rawEvent, &evt); err != nil {
+		return nil, fmt.Errorf("failed to parse EventBridge event: %w", err)
+	}
 
-		results = append(results, TaskResult{
-			TaskArn:       taskArn,
-			StoppedReason: reason,
-			IsMaintenance: isMaint,
-			IsScaling:     isScale,
+	if evt.Detail.TaskArn == "" {
+		return nil, fmt.Errorf("missing detail.taskArn in EventBridge event")
+	}
+
+	containers := make([]ecsTypes.Container, 0, len(evt.Detail.Containers))
+	for _, c := range evt.Detail.Containers {
+		containers = append(containers, ecsTypes.Container{
+			Reason:   aws.String(c.Reason),
+			ExitCode: c.ExitCode,
 		})
+	}
 
-		if !isMaint && !isScale {
-			fmt.Printf(" pager duty sent: ")
-			// Uncomment when ready to send
-			// err := sendPagerDutyAlert(ctx, splunk.Severity, splunk.Detector, splunk, taskArn, secretValue)
-			// if err != nil {
-			// 	fmt.Printf(" Failed to send PagerDuty alert: %v\n", err)
-			// } else {
-			// 	fmt.Printf("PagerDuty alert sent for task %s\n", taskArn)
-			// }
-		}
+	task := ecsTypes.Task{
+		TaskArn:       aws.String(evt.Detail.TaskArn),
+		StoppedReason: aws.String(evt.Detail.StoppedReason),
+		StopCode:      ecsTypes.TaskStopCode(evt.Detail.StopCode),
+		Containers:    containers,
+	}
+
+	meta := processor.AlertMeta{
+		Detector:    "ecs-task-state-change",
+		Status:      "stopped",
+		ServiceName: evt.Detail.TaskArn,
+		CustomDetails: map[string]interface{}{
+			"task_arn":       evt.Detail.TaskArn,
+			"stopped_reason": evt.Detail.StoppedReason,
+			"stop_code":      evt.Detail.StopCode,
+		},
 	}
 
-	return results, nil
+	return processor.Process(ctx, []ecsTypes.Task{task}, meta, engine, sinks, alertSinkTimeout(), opts)
 }
 
 // Main function to start the Lambda
 func main() {
 	ctx := context.Background()
 
+	slog.SetDefault(telemetry.NewLogger())
+
+	shutdownTracing, err := telemetry.StartTracing(ctx, "ecs-task-checker")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to start tracing", "error", err)
+		return
+	}
+	defer shutdownTracing(ctx)
+
 	cfg, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
-		fmt.Printf("Error loading AWS config: %v\n", err)
+		slog.ErrorContext(ctx, "failed to load AWS config", "error", err)
 		return
 	}
 
-	secretClient := secretsmanager.NewFromConfig(cfg)
-	ecsClient := ecs.NewFromConfig(cfg)
+	awsRetryer := func() aws.Retryer { return retry.NewAWSRetryer(5, 100*time.Millisecond, 20*time.Second) }
+	secretClient := secretsmanager.NewFromConfig(cfg, func(o *secretsmanager.Options) { o.Retryer = awsRetryer() })
+	ecsClient := ecs.NewFromConfig(cfg, func(o *ecs.Options) { o.Retryer = awsRetryer() })
+	dynamoClient := dynamodb.NewFromConfig(cfg, func(o *dynamodb.Options) { o.Retryer = awsRetryer() })
 	Environment = os.Getenv("ENVIRONMENT")
 
-	fmt.Print("environment value: ", Environment)
+	slog.InfoContext(ctx, "starting ecs-task-checker", "environment", Environment)
 
-	secretValue, err := getSecret(ctx, secretClient, "visibility-eventing@"+Environment+"_secrets")
+	sinks, err := loadAlertSinks(ctx, secretClient, Environment)
 	if err != nil {
-		fmt.Printf("Error fetching secret: %v\n", err)
+		slog.ErrorContext(ctx, "failed to load alert sinks", "error", err)
 		return
 	}
 
-	fmt.Printf("Fetched secret: %s\n", secretValue)
-
-	// Wrap the handler to inject ecsClient
-	lambda.Start(func(ctx context.Context, rawEvent json.RawMessage) ([]TaskResult, error) {
-		return handler(ctx, rawEvent, ecsClient, secretValue)
-	})
-}
+	rules, err := loadClassifyRules(ctx, secretClient, Environment)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load classify rules", "error", err)
+		return
+	}
+	engine := classify.NewEngine(rules)
+	opts := loadProcessorOptions(dynamoClient)
 
+	mode := os.Getenv("HANDLER_MODE")
+	if mode == "" {
+		mode = "splunk"
+	}
 
+	switch mode {
+	case "splunk":
+		lambda.Start(func(ctx context.Context, rawEvent json.RawMessage) ([]processor.TaskResult, error) {
+			return handler(ctx, rawEvent, ecsClient, sinks, engine, opts)
+		})
+	case "eventbridge":
+		lambda.Start(func(ctx context.Context, rawEvent json.RawMessage) ([]processor.TaskResult, error) {
+			return eventBridgeHandler(ctx, rawEvent, sinks, engine, opts)
+		})
+	default:
+		slog.ErrorContext(ctx, "unknown HANDLER_MODE", "mode", mode)
+	}
+}
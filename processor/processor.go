@@ -0,0 +1,217 @@
+// Package processor implements the classification-and-alert pipeline
+// shared by the lambda's Splunk-polling and EventBridge-driven entry
+// points: both end up with a set of ecsTypes.Task structs and hand them
+// to Process, which classifies each one and dispatches alerts.
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Debbatisudheer/Ecs-task-checker/alerts"
+	"github.com/Debbatisudheer/Ecs-task-checker/classify"
+	"github.com/Debbatisudheer/Ecs-task-checker/dedup"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TaskResult is the outcome of classifying a single stopped ECS task.
+type TaskResult struct {
+	TaskArn       string            `json:"task_arn"`
+	StoppedReason string            `json:"stopped_reason"`
+	Category      classify.Category `json:"category"`
+	IsMaintenance bool              `json:"is_maintenance"`
+	IsScaling     bool              `json:"is_scaling"`
+}
+
+// AlertMeta carries the event-source-specific context needed to render
+// an alert, independent of whether the stop signal arrived via Splunk
+// polling or an EventBridge task state change.
+type AlertMeta struct {
+	Severity      string
+	Detector      string
+	Rule          string
+	Status        string
+	Timestamp     string
+	ServiceName   string
+	CustomDetails map[string]interface{}
+}
+
+// Deduper reports whether a dedup key has already been alerted within
+// its suppression window, recording it if not.
+type Deduper interface {
+	Seen(ctx context.Context, dedupKey string) (bool, error)
+}
+
+// Options configures the optional dedup and grouping behavior of
+// Process. The zero value alerts on every eligible task individually,
+// with no deduplication.
+type Options struct {
+	// Deduper, if set, suppresses an alert when its dedup key was
+	// already seen within the configured window.
+	Deduper Deduper
+	// Group, if true, collects every CategoryUnknown task from this
+	// invocation into a single alert (custom_details.tasks as an
+	// array) instead of paging once per task.
+	Group bool
+}
+
+// taskInputFromECS extracts the fields the classification engine cares
+// about from an ECS task description.
+func taskInputFromECS(t ecsTypes.Task) classify.TaskInput {
+	containers := make([]classify.ContainerInput, 0, len(t.Containers))
+	for _, c := range t.Containers {
+		containers = append(containers, classify.ContainerInput{
+			Reason:   aws.ToString(c.Reason),
+			ExitCode: c.ExitCode,
+		})
+	}
+
+	return classify.TaskInput{
+		StoppedReason: aws.ToString(t.StoppedReason),
+		StopCode:      string(t.StopCode),
+		Containers:    containers,
+	}
+}
+
+// groupedTask is a flapping task folded into a grouped alert's
+// custom_details.tasks array.
+type groupedTask struct {
+	TaskArn       string            `json:"task_arn"`
+	StoppedReason string            `json:"stopped_reason"`
+	Category      classify.Category `json:"category"`
+}
+
+// withTraceID returns a copy of details with the active span's trace ID
+// attached, so sinks that forward custom_details (e.g. PagerDuty) let
+// operators jump from an incident straight to the trace. details itself
+// is left untouched since it's shared across every task in this
+// invocation. If ctx carries no trace ID, details is returned as is.
+func withTraceID(ctx context.Context, details map[string]interface{}) map[string]interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return details
+	}
+
+	out := make(map[string]interface{}, len(details)+1)
+	for k, v := range details {
+		out[k] = v
+	}
+	out["trace_id"] = sc.TraceID().String()
+	return out
+}
+
+// Process classifies each task with engine and dispatches an alert to
+// sinks for any task whose category should page, per engine's rule
+// table. meta supplies the event-source context (severity, detector,
+// custom details, ...) used to render the alert; opts controls
+// deduplication and grouping.
+func Process(ctx context.Context, tasks []ecsTypes.Task, meta AlertMeta, engine *classify.Engine, sinks []alerts.AlertSink, sinkTimeout time.Duration, opts Options) ([]TaskResult, error) {
+	var results []TaskResult
+	var grouped []groupedTask
+
+	customDetailsWithTrace := withTraceID(ctx, meta.CustomDetails)
+
+	for _, t := range tasks {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("operation canceled during processing: %w", ctx.Err())
+		default:
+		}
+
+		taskArn := aws.ToString(t.TaskArn)
+		reason := strings.ToLower(aws.ToString(t.StoppedReason))
+		category := engine.Classify(taskInputFromECS(t))
+
+		results = append(results, TaskResult{
+			TaskArn:       taskArn,
+			StoppedReason: reason,
+			Category:      category,
+			IsMaintenance: category == classify.CategoryMaintenance,
+			IsScaling:     category == classify.CategoryScaling,
+		})
+
+		if !engine.ShouldAlert(category) {
+			continue
+		}
+
+		dedupKey := dedup.Key(meta.ServiceName, taskArn, reason)
+		if opts.Deduper != nil {
+			seen, err := opts.Deduper.Seen(ctx, dedupKey)
+			if err != nil {
+				slog.ErrorContext(ctx, "dedup check failed", "task_arn", taskArn, "dedup_key", dedupKey, "error", err)
+			} else if seen {
+				slog.InfoContext(ctx, "suppressing duplicate alert", "task_arn", taskArn, "dedup_key", dedupKey, "stopped_reason", reason, "category", category)
+				continue
+			}
+		}
+
+		if opts.Group && category == classify.CategoryUnknown {
+			grouped = append(grouped, groupedTask{TaskArn: taskArn, StoppedReason: reason, Category: category})
+			continue
+		}
+
+		dispatchAlert(ctx, sinks, sinkTimeout, alerts.AlertEvent{
+			Severity:      meta.Severity,
+			Detector:      meta.Detector,
+			Summary:       fmt.Sprintf("Critical Alert: %s (%s)", meta.Severity, meta.Detector),
+			ServiceName:   meta.ServiceName,
+			TaskArn:       taskArn,
+			Rule:          meta.Rule,
+			Status:        meta.Status,
+			Timestamp:     meta.Timestamp,
+			CustomDetails: customDetailsWithTrace,
+			DedupKey:      dedupKey,
+		}, category, reason)
+	}
+
+	if len(grouped) > 0 {
+		customDetails := map[string]interface{}{"tasks": grouped}
+		for k, v := range meta.CustomDetails {
+			customDetails[k] = v
+		}
+
+		taskArns := make([]string, len(grouped))
+		for i, g := range grouped {
+			taskArns[i] = g.TaskArn
+		}
+
+		dispatchAlert(ctx, sinks, sinkTimeout, alerts.AlertEvent{
+			Severity:      meta.Severity,
+			Detector:      meta.Detector,
+			Summary:       fmt.Sprintf("Critical Alert: %d tasks stopped with an unknown reason (%s)", len(grouped), meta.ServiceName),
+			ServiceName:   meta.ServiceName,
+			TaskArn:       grouped[0].TaskArn,
+			Rule:          meta.Rule,
+			Status:        meta.Status,
+			Timestamp:     meta.Timestamp,
+			CustomDetails: withTraceID(ctx, customDetails),
+			DedupKey:      dedup.Key(meta.ServiceName, strings.Join(taskArns, ","), "grouped-unknown"),
+		}, classify.CategoryUnknown, "grouped")
+	}
+
+	return results, nil
+}
+
+// dispatchAlert fans event out to sinks and logs the outcome with the
+// fields operators filter on in CloudWatch Logs Insights: service,
+// task_arn, stopped_reason, category, and dedup_key.
+func dispatchAlert(ctx context.Context, sinks []alerts.AlertSink, sinkTimeout time.Duration, event alerts.AlertEvent, category classify.Category, stoppedReason string) {
+	logArgs := []any{
+		"service", event.ServiceName,
+		"task_arn", event.TaskArn,
+		"stopped_reason", stoppedReason,
+		"category", category,
+		"dedup_key", event.DedupKey,
+	}
+
+	if err := alerts.Dispatch(ctx, sinks, event, sinkTimeout); err != nil {
+		slog.ErrorContext(ctx, "failed to dispatch alert", append(logArgs, "error", err)...)
+	} else {
+		slog.InfoContext(ctx, "alert dispatched", logArgs...)
+	}
+}
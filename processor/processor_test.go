@@ -0,0 +1,134 @@
+package processor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Debbatisudheer/Ecs-task-checker/alerts"
+	"github.com/Debbatisudheer/Ecs-task-checker/classify"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ecsTypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// fakeSink records every event it receives instead of sending it anywhere.
+type fakeSink struct {
+	mu     sync.Mutex
+	events []alerts.AlertEvent
+}
+
+func (f *fakeSink) Name() string { return "fake" }
+
+func (f *fakeSink) Notify(ctx context.Context, event alerts.AlertEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeSink) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.events)
+}
+
+// fakeDeduper reports a key as seen on every call after its first.
+type fakeDeduper struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newFakeDeduper() *fakeDeduper {
+	return &fakeDeduper{seen: map[string]bool{}}
+}
+
+func (f *fakeDeduper) Seen(ctx context.Context, dedupKey string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.seen[dedupKey] {
+		return true, nil
+	}
+	f.seen[dedupKey] = true
+	return false, nil
+}
+
+func stoppedTask(arn, reason string) ecsTypes.Task {
+	return ecsTypes.Task{TaskArn: aws.String(arn), StoppedReason: aws.String(reason)}
+}
+
+func TestProcessAlertsOnEligibleCategory(t *testing.T) {
+	sink := &fakeSink{}
+	engine := classify.NewEngine(classify.DefaultRules())
+
+	results, err := Process(context.Background(), []ecsTypes.Task{stoppedTask("arn:1", "health check failed")},
+		AlertMeta{ServiceName: "svc"}, engine, []alerts.AlertSink{sink}, time.Second, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Category != classify.CategoryHealthCheckFailed {
+		t.Fatalf("expected one HealthCheckFailed result, got %+v", results)
+	}
+	if sink.count() != 1 {
+		t.Fatalf("expected one dispatched alert, got %d", sink.count())
+	}
+}
+
+func TestProcessSuppressesNonAlertingCategory(t *testing.T) {
+	sink := &fakeSink{}
+	engine := classify.NewEngine(classify.DefaultRules())
+
+	results, err := Process(context.Background(), []ecsTypes.Task{stoppedTask("arn:1", "maintenance")},
+		AlertMeta{ServiceName: "svc"}, engine, []alerts.AlertSink{sink}, time.Second, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].IsMaintenance != true {
+		t.Fatalf("expected one Maintenance result, got %+v", results)
+	}
+	if sink.count() != 0 {
+		t.Fatalf("expected no dispatched alerts for Maintenance, got %d", sink.count())
+	}
+}
+
+func TestProcessDedupsRepeatedTask(t *testing.T) {
+	sink := &fakeSink{}
+	engine := classify.NewEngine(classify.DefaultRules())
+	opts := Options{Deduper: newFakeDeduper()}
+
+	task := stoppedTask("arn:1", "health check failed")
+	meta := AlertMeta{ServiceName: "svc"}
+
+	if _, err := Process(context.Background(), []ecsTypes.Task{task}, meta, engine, []alerts.AlertSink{sink}, time.Second, opts); err != nil {
+		t.Fatalf("unexpected error on first call: %v", err)
+	}
+	if _, err := Process(context.Background(), []ecsTypes.Task{task}, meta, engine, []alerts.AlertSink{sink}, time.Second, opts); err != nil {
+		t.Fatalf("unexpected error on second call: %v", err)
+	}
+
+	if sink.count() != 1 {
+		t.Fatalf("expected the second identical task to be suppressed, got %d dispatched alerts", sink.count())
+	}
+}
+
+func TestProcessGroupsUnknownCategory(t *testing.T) {
+	sink := &fakeSink{}
+	engine := classify.NewEngine(classify.DefaultRules())
+	opts := Options{Group: true}
+
+	tasks := []ecsTypes.Task{
+		stoppedTask("arn:1", "no rule matches this"),
+		stoppedTask("arn:2", "no rule matches this either"),
+	}
+
+	results, err := Process(context.Background(), tasks, AlertMeta{ServiceName: "svc"}, engine, []alerts.AlertSink{sink}, time.Second, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected a TaskResult per task, got %d", len(results))
+	}
+	if sink.count() != 1 {
+		t.Fatalf("expected the two Unknown tasks to fold into a single grouped alert, got %d", sink.count())
+	}
+}